@@ -0,0 +1,138 @@
+// Package extract pulls the main content out of an HTML document, stripping
+// navigation, ads, and other boilerplate that would otherwise pollute word
+// counts and summaries.
+package extract
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// chromeElements are dropped outright, along with all of their descendants.
+var chromeElements = map[string]bool{
+	"script": true,
+	"style":  true,
+	"nav":    true,
+	"header": true,
+	"footer": true,
+	"aside":  true,
+}
+
+// blockElements are the candidate containers scored for "main content-ness".
+var blockElements = map[string]bool{
+	"p":       true,
+	"article": true,
+	"section": true,
+	"div":     true,
+	"li":      true,
+}
+
+// chromePattern matches class/id naming conventions commonly used for
+// navigation and other non-content chrome.
+var chromePattern = regexp.MustCompile(`(?i)nav|menu|footer|sidebar|comment|share`)
+
+// MainContent walks doc and returns the text of the highest-scoring block
+// element, approximating a Readability-style boilerplate strip. If no block
+// element scores above zero, it falls back to the full document's text.
+func MainContent(doc *html.Node) string {
+	best := bestCandidate(doc)
+	if best == nil {
+		return strings.TrimSpace(text(doc))
+	}
+	return strings.TrimSpace(text(best))
+}
+
+func bestCandidate(root *html.Node) *html.Node {
+	var best *html.Node
+	var bestScore float64
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			if chromeElements[n.Data] {
+				return
+			}
+			if blockElements[n.Data] && !isChrome(n) {
+				if score := score(n); score > bestScore {
+					best, bestScore = n, score
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(root)
+
+	return best
+}
+
+// score approximates Readability's text-density heuristic: more text wins,
+// but link-heavy blocks (nav menus) and punctuation-heavy blocks (share
+// bars, comment counts) are penalized.
+func score(n *html.Node) float64 {
+	content := text(n)
+	textLen := float64(len(content))
+	if textLen == 0 {
+		return 0
+	}
+
+	linkDensity := float64(len(linkText(n))) / textLen
+
+	punct := strings.Count(content, ",") + strings.Count(content, ".") + strings.Count(content, ";")
+	punctDensity := float64(punct) / textLen
+
+	return textLen * (1 - linkDensity) * (1 - 0.5*punctDensity)
+}
+
+func linkText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.Data == "a" {
+			b.WriteString(text(n))
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	return b.String()
+}
+
+// isChrome reports whether n, or any element it sits inside, has a class or
+// id that matches a common non-content naming pattern.
+func isChrome(n *html.Node) bool {
+	for p := n; p != nil; p = p.Parent {
+		if p.Type != html.ElementNode {
+			continue
+		}
+		for _, attr := range p.Attr {
+			if (attr.Key == "class" || attr.Key == "id") && chromePattern.MatchString(attr.Val) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// text concatenates the text content of n, skipping chromeElements entirely.
+func text(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return strings.TrimSpace(n.Data)
+	}
+	if n.Type != html.ElementNode {
+		return ""
+	}
+	if chromeElements[n.Data] {
+		return ""
+	}
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(" ")
+		b.WriteString(text(c))
+	}
+	return b.String()
+}
@@ -0,0 +1,166 @@
+// Package httpx wraps net/http with the fetching behavior succinct needs:
+// a single request per URL, bounded redirect tracking, retry-with-backoff
+// on 429/5xx, and content-type gating so non-HTML responses are skipped.
+package httpx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNonHTML is returned (alongside a populated FetchResult) when the
+// response's Content-Type is not HTML.
+var ErrNonHTML = errors.New("httpx: response is not HTML")
+
+// FetchResult describes the outcome of a successful fetch.
+type FetchResult struct {
+	Body         []byte
+	StatusCode   int
+	FinalURL     string
+	RedirectHops []string
+	ContentType  string
+}
+
+// Client fetches URLs with redirect tracking, retries, and content-type
+// gating applied uniformly.
+type Client struct {
+	HTTPClient   *http.Client
+	UserAgent    string
+	MaxRedirects int
+	MaxRetries   int
+	Backoff      time.Duration
+}
+
+// NewClient constructs a Client. maxRedirects bounds the redirect chain
+// length and maxRetries bounds retry attempts on 429/5xx responses.
+func NewClient(userAgent string, maxRedirects, maxRetries int) *Client {
+	return &Client{
+		HTTPClient:   &http.Client{Timeout: 10 * time.Second},
+		UserAgent:    userAgent,
+		MaxRedirects: maxRedirects,
+		MaxRetries:   maxRetries,
+		Backoff:      500 * time.Millisecond,
+	}
+}
+
+// Fetch issues a single logical GET against rawURL, following redirects (up
+// to MaxRedirects) and retrying 429/5xx responses (up to MaxRetries) with
+// exponential backoff honoring Retry-After. If the final response is not
+// HTML, it returns ErrNonHTML alongside a FetchResult describing what was
+// skipped and why.
+func (c *Client) Fetch(ctx context.Context, rawURL string) (*FetchResult, error) {
+	url := normalizeURL(rawURL)
+
+	var hops []string
+	client := &http.Client{
+		Timeout: c.HTTPClient.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= c.MaxRedirects {
+				return fmt.Errorf("httpx: stopped after %d redirects", c.MaxRedirects)
+			}
+			hops = append(hops, req.URL.String())
+			return nil
+		},
+	}
+
+	backoff := c.Backoff
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		hops = nil
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", c.UserAgent)
+
+		resp, err = client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isRetryable(resp.StatusCode) || attempt >= c.MaxRetries {
+			break
+		}
+
+		wait := retryAfter(resp.Header, backoff)
+		resp.Body.Close()
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff *= 2
+	}
+	defer resp.Body.Close()
+
+	contentType := resp.Header.Get("Content-Type")
+	result := &FetchResult{
+		StatusCode:   resp.StatusCode,
+		FinalURL:     resp.Request.URL.String(),
+		RedirectHops: hops,
+		ContentType:  contentType,
+	}
+
+	if !isHTML(contentType) {
+		return result, ErrNonHTML
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	result.Body = body
+
+	return result, nil
+}
+
+func isRetryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// retryAfter parses a Retry-After header (either delay-seconds or an
+// HTTP-date), falling back to fallback when the header is absent or
+// unparseable.
+func retryAfter(h http.Header, fallback time.Duration) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return fallback
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if wait := time.Until(t); wait > 0 {
+			return wait
+		}
+	}
+	return fallback
+}
+
+// isHTML reports whether contentType names an HTML media type. An empty
+// Content-Type is treated as HTML, since some servers omit it.
+func isHTML(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return strings.Contains(contentType, "html")
+	}
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+func normalizeURL(raw string) string {
+	if !strings.HasPrefix(raw, "http://") && !strings.HasPrefix(raw, "https://") {
+		return "https://" + raw
+	}
+	return raw
+}
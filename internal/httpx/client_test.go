@@ -0,0 +1,66 @@
+package httpx
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryAfterSeconds(t *testing.T) {
+	h := http.Header{}
+	h.Set("Retry-After", "5")
+	got := retryAfter(h, time.Second)
+	if got != 5*time.Second {
+		t.Errorf("retryAfter() = %v, want 5s", got)
+	}
+}
+
+func TestRetryAfterHTTPDate(t *testing.T) {
+	future := time.Now().Add(10 * time.Second).UTC()
+	h := http.Header{}
+	h.Set("Retry-After", future.Format(http.TimeFormat))
+	got := retryAfter(h, time.Second)
+	if got <= 0 || got > 11*time.Second {
+		t.Errorf("retryAfter() = %v, want ~10s", got)
+	}
+}
+
+func TestRetryAfterFallback(t *testing.T) {
+	cases := []struct {
+		name  string
+		value string
+	}{
+		{"missing header", ""},
+		{"unparseable value", "not-a-duration"},
+	}
+	for _, c := range cases {
+		h := http.Header{}
+		if c.value != "" {
+			h.Set("Retry-After", c.value)
+		}
+		got := retryAfter(h, 2*time.Second)
+		if got != 2*time.Second {
+			t.Errorf("%s: retryAfter() = %v, want fallback 2s", c.name, got)
+		}
+	}
+}
+
+func TestIsHTML(t *testing.T) {
+	cases := []struct {
+		contentType string
+		want        bool
+	}{
+		{"", true},
+		{"text/html", true},
+		{"text/html; charset=utf-8", true},
+		{"application/xhtml+xml", true},
+		{"application/json", false},
+		{"image/png", false},
+		{"not a media type;;;", false},
+	}
+	for _, c := range cases {
+		if got := isHTML(c.contentType); got != c.want {
+			t.Errorf("isHTML(%q) = %v, want %v", c.contentType, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Package politeness implements crawl-politeness controls: per-host
+// robots.txt checks and per-host request rate limiting.
+package politeness
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+)
+
+// Gate decides whether a URL may be fetched and, if so, waits for its turn
+// under the configured per-host rate limit.
+type Gate struct {
+	userAgent string
+	rps       float64
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	robots   map[string]*robotsRules
+}
+
+// NewGate creates a Gate that allows rps requests per second per host,
+// identifying itself with userAgent both in robots.txt group matching and in
+// the User-Agent header callers should send on every request.
+func NewGate(rps float64, userAgent string) *Gate {
+	if rps <= 0 {
+		rps = 1
+	}
+	if userAgent == "" {
+		userAgent = "succinct"
+	}
+	return &Gate{
+		userAgent: userAgent,
+		rps:       rps,
+		limiters:  make(map[string]*rate.Limiter),
+		robots:    make(map[string]*robotsRules),
+	}
+}
+
+// UserAgent returns the User-Agent string callers should send alongside
+// every gated request.
+func (g *Gate) UserAgent() string {
+	return g.userAgent
+}
+
+// Allow reports whether robots.txt permits fetching rawURL and, if it does,
+// blocks until the per-host rate limit allows the fetch. A URL disallowed by
+// robots.txt returns at once with ok false and reason explaining why it was
+// skipped.
+func (g *Gate) Allow(ctx context.Context, rawURL string) (ok bool, reason string, err error) {
+	u, err := url.Parse(normalizeURL(rawURL))
+	if err != nil {
+		return false, "", fmt.Errorf("parsing URL: %w", err)
+	}
+
+	rules, err := g.rulesFor(ctx, u)
+	if err != nil {
+		// Fail open: a robots.txt we couldn't fetch shouldn't block the crawl.
+		rules = allowAll
+	}
+
+	if !rules.allowed(u.Path, g.userAgent) {
+		return false, fmt.Sprintf("disallowed by robots.txt for user-agent %q", g.userAgent), nil
+	}
+
+	if err := g.limiterFor(u.Host).Wait(ctx); err != nil {
+		return false, "", err
+	}
+
+	return true, "", nil
+}
+
+// Wait blocks until host's per-host rate limit allows another request,
+// without a robots.txt check. It's meant for the meta-discovery requests
+// (robots.txt itself, sitemap.xml) that a crawl issues ahead of the actual
+// page fetches Allow gates, which still shouldn't go out unthrottled.
+func (g *Gate) Wait(ctx context.Context, host string) error {
+	return g.limiterFor(host).Wait(ctx)
+}
+
+func (g *Gate) limiterFor(host string) *rate.Limiter {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	lim, ok := g.limiters[host]
+	if !ok {
+		lim = rate.NewLimiter(rate.Limit(g.rps), 1)
+		g.limiters[host] = lim
+	}
+	return lim
+}
+
+func (g *Gate) rulesFor(ctx context.Context, u *url.URL) (*robotsRules, error) {
+	g.mu.Lock()
+	if rules, ok := g.robots[u.Host]; ok {
+		g.mu.Unlock()
+		return rules, nil
+	}
+	g.mu.Unlock()
+
+	rules, err := fetchRobots(ctx, u, g.userAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	g.robots[u.Host] = rules
+	g.mu.Unlock()
+
+	return rules, nil
+}
+
+// normalizeURL prepends a scheme to rawURL if it's missing, matching the
+// bare host/path URLs this tool's targets files commonly contain (same
+// convention as httpx.normalizeURL and sitemap.normalizeURL).
+func normalizeURL(raw string) string {
+	if !strings.HasPrefix(raw, "http://") && !strings.HasPrefix(raw, "https://") {
+		return "https://" + raw
+	}
+	return raw
+}
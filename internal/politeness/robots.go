@@ -0,0 +1,147 @@
+package politeness
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// allowAll is used whenever a host has no robots.txt, or it could not be
+// fetched or parsed; a nil *robotsRules also means "allow everything".
+var allowAll = (*robotsRules)(nil)
+
+// robotsRules holds the Disallow/Allow prefixes from the robots.txt group
+// that applies to a single user-agent.
+type robotsRules struct {
+	disallow []string
+	allow    []string
+}
+
+// allowed reports whether path may be fetched, using the longest matching
+// Allow/Disallow prefix; ties favor Allow, matching common robots.txt
+// implementations.
+func (r *robotsRules) allowed(path, userAgent string) bool {
+	if r == nil {
+		return true
+	}
+
+	matchLen := -1
+	result := true
+	for _, p := range r.disallow {
+		if strings.HasPrefix(path, p) && len(p) > matchLen {
+			matchLen = len(p)
+			result = false
+		}
+	}
+	for _, p := range r.allow {
+		if strings.HasPrefix(path, p) && len(p) >= matchLen {
+			matchLen = len(p)
+			result = true
+		}
+	}
+	return result
+}
+
+// fetchRobots fetches and parses /robots.txt for target's host.
+func fetchRobots(ctx context.Context, target *url.URL, userAgent string) (*robotsRules, error) {
+	robotsURL := url.URL{Scheme: target.Scheme, Host: target.Host, Path: "/robots.txt"}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return allowAll, nil
+	}
+
+	return parseRobots(resp.Body, userAgent), nil
+}
+
+// parseRobots reads a robots.txt body and returns the Allow/Disallow rules
+// for the group that matches userAgent, falling back to the "*" group.
+func parseRobots(body io.Reader, userAgent string) *robotsRules {
+	scanner := bufio.NewScanner(body)
+
+	groups := map[string]*robotsRules{}
+	var groupOrder []string
+	var groupUAs []string
+	sawRule := false
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		field, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		field = strings.ToLower(strings.TrimSpace(field))
+		value = strings.TrimSpace(value)
+
+		switch field {
+		case "user-agent":
+			// A User-agent line seen after rules starts a new group.
+			if sawRule {
+				groupUAs = nil
+				sawRule = false
+			}
+			ua := strings.ToLower(value)
+			groupUAs = append(groupUAs, ua)
+			if _, ok := groups[ua]; !ok {
+				groups[ua] = &robotsRules{}
+				groupOrder = append(groupOrder, ua)
+			}
+		case "disallow":
+			sawRule = true
+			if value == "" {
+				continue
+			}
+			for _, ua := range groupUAs {
+				groups[ua].disallow = append(groups[ua].disallow, value)
+			}
+		case "allow":
+			sawRule = true
+			for _, ua := range groupUAs {
+				groups[ua].allow = append(groups[ua].allow, value)
+			}
+		}
+	}
+
+	ua := strings.ToLower(userAgent)
+
+	// Real crawlers match a group's declared product token against their
+	// own User-Agent as a substring, not as an exact equal string (our
+	// own User-Agent is a full string like "succinct/1.0 (+https://...)",
+	// while robots.txt commonly declares just "succinct"). When more than
+	// one declared token matches, the longest (most specific) one wins.
+	var best *robotsRules
+	bestLen := -1
+	for _, token := range groupOrder {
+		if token == "" || token == "*" {
+			continue
+		}
+		if strings.Contains(ua, token) && len(token) > bestLen {
+			best = groups[token]
+			bestLen = len(token)
+		}
+	}
+	if best != nil {
+		return best
+	}
+	if rules, ok := groups["*"]; ok {
+		return rules
+	}
+	return allowAll
+}
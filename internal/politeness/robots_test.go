@@ -0,0 +1,73 @@
+package politeness
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRobotsMatchesProductTokenAsSubstring(t *testing.T) {
+	body := "User-agent: succinct\nDisallow: /private\n\nUser-agent: *\nDisallow: /\n"
+
+	// The real User-Agent header is a full string, not the bare product
+	// token robots.txt declares; the group lookup must still find it.
+	rules := parseRobots(strings.NewReader(body), "succinct/1.0 (+https://github.com/codingo/succinct)")
+	if rules.allowed("/private/x", "succinct/1.0 (+https://github.com/codingo/succinct)") {
+		t.Error("expected /private/x to be disallowed for a UA containing the \"succinct\" product token")
+	}
+	if !rules.allowed("/public", "succinct/1.0 (+https://github.com/codingo/succinct)") {
+		t.Error("expected /public to be allowed by the succinct group, not fall through to the * group")
+	}
+}
+
+func TestParseRobotsFallsBackToWildcard(t *testing.T) {
+	body := "User-agent: googlebot\nDisallow: /\n\nUser-agent: *\nDisallow: /private\n"
+
+	rules := parseRobots(strings.NewReader(body), "succinct/1.0")
+	if rules.allowed("/private/x", "succinct/1.0") {
+		t.Error("expected /private/x to be disallowed by the wildcard group")
+	}
+	if !rules.allowed("/public", "succinct/1.0") {
+		t.Error("expected /public to be allowed")
+	}
+}
+
+func TestParseRobotsPrefersLongestMatchingToken(t *testing.T) {
+	body := "User-agent: succinct\nDisallow: /a\n\nUser-agent: succinctbot\nDisallow: /b\n"
+
+	rules := parseRobots(strings.NewReader(body), "succinctbot/2.0")
+	if rules.allowed("/b/x", "succinctbot/2.0") {
+		t.Error("expected the longer, more specific \"succinctbot\" group to apply")
+	}
+	if !rules.allowed("/a/x", "succinctbot/2.0") {
+		t.Error("expected the shorter \"succinct\" group's rule not to apply here")
+	}
+}
+
+func TestParseRobotsNoMatchAllowsAll(t *testing.T) {
+	body := "User-agent: googlebot\nDisallow: /\n"
+
+	rules := parseRobots(strings.NewReader(body), "succinct/1.0")
+	if !rules.allowed("/anything", "succinct/1.0") {
+		t.Error("expected allow-all when no group matches and there's no wildcard group")
+	}
+}
+
+func TestRobotsRulesAllowedLongestMatchWins(t *testing.T) {
+	rules := &robotsRules{
+		disallow: []string{"/a"},
+		allow:    []string{"/a/b"},
+	}
+	if !rules.allowed("/a/b/c", "any") {
+		t.Error("expected the longer Allow prefix to win over the shorter Disallow prefix")
+	}
+	if rules.allowed("/a/x", "any") {
+		t.Error("expected /a/x to remain disallowed")
+	}
+}
+
+func TestRobotsRulesNilAllowsAll(t *testing.T) {
+	var rules *robotsRules
+	if !rules.allowed("/anything", "any") {
+		t.Error("expected a nil *robotsRules to allow everything")
+	}
+}
@@ -0,0 +1,128 @@
+// Package report defines the shape of succinct's per-URL output and knows
+// how to render a batch of it as text, JSON, CSV, or NDJSON.
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WordCount pairs a word (or n-gram) with its rank score: a raw occurrence
+// count in count-rank mode, or a TF-IDF weight in tfidf-rank mode.
+type WordCount struct {
+	Word  string  `json:"word"`
+	Count float64 `json:"count"`
+}
+
+// Result is the outcome of processing a single URL.
+type Result struct {
+	URL          string      `json:"url"`
+	FinalURL     string      `json:"final_url"`
+	RedirectHops []string    `json:"redirect_hops"`
+	HTTPStatus   int         `json:"http_status"`
+	FetchedBytes int         `json:"fetched_bytes"`
+	ElapsedMS    int64       `json:"elapsed_ms"`
+	TopWords     []WordCount `json:"top_words"`
+	Summary      []string    `json:"summary"`
+}
+
+// Format identifies one of the supported output renderings.
+type Format string
+
+const (
+	Text   Format = "text"
+	JSON   Format = "json"
+	CSV    Format = "csv"
+	NDJSON Format = "ndjson"
+)
+
+// ParseFormat validates a -format flag value.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case Text, JSON, CSV, NDJSON:
+		return Format(s), nil
+	default:
+		return "", fmt.Errorf("unknown format %q (want text, json, csv, or ndjson)", s)
+	}
+}
+
+// Write renders results to w in the given format.
+func Write(w io.Writer, format Format, results []Result) error {
+	switch format {
+	case JSON:
+		return writeJSON(w, results)
+	case CSV:
+		return writeCSV(w, results)
+	case NDJSON:
+		return writeNDJSON(w, results)
+	case Text, "":
+		return writeText(w, results)
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+}
+
+func writeText(w io.Writer, results []Result) error {
+	for _, res := range results {
+		fmt.Fprintf(w, "\nMost used words for %s (status %d", res.URL, res.HTTPStatus)
+		if len(res.RedirectHops) > 0 {
+			fmt.Fprintf(w, ", %d redirect(s) to %s", len(res.RedirectHops), res.FinalURL)
+		}
+		fmt.Fprintf(w, "):\n")
+		for _, wc := range res.TopWords {
+			fmt.Fprintf(w, "%s: %s\n", wc.Word, strconv.FormatFloat(wc.Count, 'f', -1, 64))
+		}
+		fmt.Fprintf(w, "\nSummary for %s:\n%s\n", res.URL, strings.Join(res.Summary, " "))
+	}
+	return nil
+}
+
+func writeJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(results)
+}
+
+func writeNDJSON(w io.Writer, results []Result) error {
+	enc := json.NewEncoder(w)
+	for _, res := range results {
+		if err := enc.Encode(res); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeCSV(w io.Writer, results []Result) error {
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	if err := cw.Write([]string{"url", "final_url", "redirect_hops", "http_status", "fetched_bytes", "elapsed_ms", "top_words", "summary"}); err != nil {
+		return err
+	}
+
+	for _, res := range results {
+		words := make([]string, len(res.TopWords))
+		for i, wc := range res.TopWords {
+			words[i] = fmt.Sprintf("%s:%s", wc.Word, strconv.FormatFloat(wc.Count, 'f', -1, 64))
+		}
+		record := []string{
+			res.URL,
+			res.FinalURL,
+			strings.Join(res.RedirectHops, " -> "),
+			strconv.Itoa(res.HTTPStatus),
+			strconv.Itoa(res.FetchedBytes),
+			strconv.FormatInt(res.ElapsedMS, 10),
+			strings.Join(words, ";"),
+			strings.Join(res.Summary, " "),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	return cw.Error()
+}
@@ -0,0 +1,202 @@
+// Package sitemap discovers a site's page URLs from a seed URL by reading
+// its robots.txt Sitemap: directives and walking the referenced
+// sitemap.xml / sitemapindex documents.
+package sitemap
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/codingo/succinct/internal/politeness"
+)
+
+type urlSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	URLs    []struct {
+		Loc string `xml:"loc"`
+	} `xml:"url"`
+}
+
+type sitemapIndex struct {
+	XMLName  xml.Name `xml:"sitemapindex"`
+	Sitemaps []struct {
+		Loc string `xml:"loc"`
+	} `xml:"sitemap"`
+}
+
+// Discover crawls outward from seed: it reads robots.txt for Sitemap:
+// directives (falling back to /sitemap.xml if there are none), then walks
+// each sitemap, recursing through sitemapindex documents, until it has
+// collected up to maxPages distinct page URLs. Every request it issues,
+// including the robots.txt and sitemap fetches themselves, waits on gate's
+// per-host rate limit first, the same as the page fetches that follow.
+func Discover(ctx context.Context, client *http.Client, gate *politeness.Gate, userAgent, seed string, maxPages int) ([]string, error) {
+	u, err := url.Parse(normalizeURL(seed))
+	if err != nil {
+		return nil, fmt.Errorf("sitemap: parsing seed URL %q: %w", seed, err)
+	}
+
+	queue, err := robotsSitemaps(ctx, client, gate, userAgent, u)
+	if err != nil {
+		return nil, err
+	}
+	if len(queue) == 0 {
+		queue = []string{fmt.Sprintf("%s://%s/sitemap.xml", u.Scheme, u.Host)}
+	}
+
+	var pages []string
+	visited := make(map[string]bool)
+	seenPages := make(map[string]bool)
+
+	for len(queue) > 0 && len(pages) < maxPages {
+		next := queue[0]
+		queue = queue[1:]
+		if visited[next] {
+			continue
+		}
+		visited[next] = true
+
+		locs, isIndex, err := fetchSitemap(ctx, client, gate, userAgent, next)
+		if err != nil {
+			// Best-effort discovery: an unreachable or malformed sitemap
+			// just yields fewer pages, not a hard failure.
+			continue
+		}
+		if isIndex {
+			queue = append(queue, locs...)
+			continue
+		}
+		for _, loc := range locs {
+			if len(pages) >= maxPages {
+				break
+			}
+			if seenPages[loc] {
+				continue
+			}
+			seenPages[loc] = true
+			pages = append(pages, loc)
+		}
+	}
+
+	return pages, nil
+}
+
+// robotsSitemaps fetches seed's robots.txt and returns the URLs named by
+// its Sitemap: directives. A robots.txt that answers with any status other
+// than 200 OK yields no error and no sitemaps, letting the caller fall back
+// to /sitemap.xml; a failed request or body read is returned as an error.
+func robotsSitemaps(ctx context.Context, client *http.Client, gate *politeness.Gate, userAgent string, seed *url.URL) ([]string, error) {
+	robotsURL := fmt.Sprintf("%s://%s/robots.txt", seed.Scheme, seed.Host)
+
+	if err := gate.Wait(ctx, seed.Host); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var sitemaps []string
+	for _, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimSpace(line)
+		const prefix = "sitemap:"
+		if !strings.HasPrefix(strings.ToLower(line), prefix) {
+			continue
+		}
+		sitemaps = append(sitemaps, strings.TrimSpace(line[len(prefix):]))
+	}
+	return sitemaps, nil
+}
+
+// fetchSitemap fetches and parses one sitemap document, transparently
+// gunzipping it when needed. It reports the URLs it found and whether they
+// name child sitemaps (a sitemapindex) or pages (a urlset).
+func fetchSitemap(ctx context.Context, client *http.Client, gate *politeness.Gate, userAgent, sitemapURL string) (locs []string, isIndex bool, err error) {
+	u, err := url.Parse(sitemapURL)
+	if err != nil {
+		return nil, false, err
+	}
+	if err := gate.Wait(ctx, u.Host); err != nil {
+		return nil, false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sitemapURL, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, false, fmt.Errorf("sitemap: unexpected status %d for %s", resp.StatusCode, sitemapURL)
+	}
+
+	var reader io.Reader = resp.Body
+	if strings.HasSuffix(sitemapURL, ".gz") || resp.Header.Get("Content-Encoding") == "gzip" {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, false, err
+		}
+		defer gz.Close()
+		reader = gz
+	}
+
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var index sitemapIndex
+	if err := xml.Unmarshal(body, &index); err == nil && len(index.Sitemaps) > 0 {
+		locs = make([]string, len(index.Sitemaps))
+		for i, s := range index.Sitemaps {
+			locs[i] = s.Loc
+		}
+		return locs, true, nil
+	}
+
+	var set urlSet
+	if err := xml.Unmarshal(body, &set); err != nil {
+		return nil, false, fmt.Errorf("sitemap: parsing %s: %w", sitemapURL, err)
+	}
+	locs = make([]string, len(set.URLs))
+	for i, u := range set.URLs {
+		locs[i] = u.Loc
+	}
+	return locs, false, nil
+}
+
+func normalizeURL(raw string) string {
+	if !strings.HasPrefix(raw, "http://") && !strings.HasPrefix(raw, "https://") {
+		return "https://" + raw
+	}
+	return raw
+}
@@ -0,0 +1,80 @@
+package summarize
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Ollama summarizes content using a local (or remote) Ollama server's
+// generate API.
+type Ollama struct {
+	Model   string
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewOllama constructs an Ollama summarizer. model defaults to "llama3" and
+// baseURL defaults to the standard local Ollama address.
+func NewOllama(model, baseURL string) *Ollama {
+	if model == "" {
+		model = "llama3"
+	}
+	if baseURL == "" {
+		baseURL = "http://localhost:11434"
+	}
+	return &Ollama{
+		Model:   model,
+		BaseURL: baseURL,
+		Client:  &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (o *Ollama) Summarize(content string, n int) ([]string, error) {
+	if n < 1 {
+		return nil, errors.New("n should be greater than or equal to 1")
+	}
+
+	prompt := fmt.Sprintf("Summarize the following text in exactly %d sentences. Reply with one sentence per line and no other commentary:\n\n%s", n, content)
+
+	body, err := json.Marshal(map[string]any{
+		"model":  o.Model,
+		"prompt": prompt,
+		"stream": false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.Client.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.BaseURL+"/api/generate", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ollama summarizer: unexpected status %d", resp.StatusCode)
+	}
+
+	var parsed struct {
+		Response string `json:"response"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	return splitLines(parsed.Response, n), nil
+}
@@ -0,0 +1,91 @@
+package summarize
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// OpenAI summarizes content by asking an OpenAI chat completion endpoint for
+// n sentences.
+type OpenAI struct {
+	APIKey  string
+	Model   string
+	BaseURL string
+	Client  *http.Client
+}
+
+// NewOpenAI constructs an OpenAI summarizer. apiKey is required at
+// Summarize time; model defaults to "gpt-4o-mini".
+func NewOpenAI(apiKey, model string) *OpenAI {
+	if model == "" {
+		model = "gpt-4o-mini"
+	}
+	return &OpenAI{
+		APIKey:  apiKey,
+		Model:   model,
+		BaseURL: "https://api.openai.com/v1",
+		Client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+func (o *OpenAI) Summarize(content string, n int) ([]string, error) {
+	if n < 1 {
+		return nil, errors.New("n should be greater than or equal to 1")
+	}
+	if o.APIKey == "" {
+		return nil, errors.New("openai summarizer requires an API key (-openai-key or OPENAI_API_KEY)")
+	}
+
+	prompt := fmt.Sprintf("Summarize the following text in exactly %d sentences. Reply with one sentence per line and no other commentary:\n\n%s", n, content)
+
+	body, err := json.Marshal(map[string]any{
+		"model": o.Model,
+		"messages": []map[string]string{
+			{"role": "user", "content": prompt},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.Client.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.BaseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+o.APIKey)
+
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openai summarizer: unexpected status %d", resp.StatusCode)
+	}
+
+	var parsed struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+	if len(parsed.Choices) == 0 {
+		return nil, errors.New("openai summarizer: empty response")
+	}
+
+	return splitLines(parsed.Choices[0].Message.Content, n), nil
+}
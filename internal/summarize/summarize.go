@@ -0,0 +1,75 @@
+// Package summarize provides pluggable backends for reducing page content
+// down to a handful of representative sentences.
+package summarize
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Summarizer produces up to n representative sentences from content.
+type Summarizer interface {
+	Summarize(content string, n int) ([]string, error)
+}
+
+// Kind identifies one of the supported summarizer backends.
+type Kind string
+
+const (
+	KindTLDR     Kind = "tldr"
+	KindTextRank Kind = "textrank"
+	KindOpenAI   Kind = "openai"
+	KindOllama   Kind = "ollama"
+)
+
+// ParseKind validates a -summarizer flag value.
+func ParseKind(s string) (Kind, error) {
+	switch Kind(s) {
+	case KindTLDR, KindTextRank, KindOpenAI, KindOllama:
+		return Kind(s), nil
+	default:
+		return "", fmt.Errorf("unknown summarizer %q (want tldr, textrank, openai, or ollama)", s)
+	}
+}
+
+// Config carries the options needed to construct any backend; fields that
+// don't apply to the chosen Kind are ignored.
+type Config struct {
+	OpenAIAPIKey string
+	OpenAIModel  string
+	OllamaModel  string
+	OllamaURL    string
+}
+
+// New constructs the Summarizer for the given kind.
+func New(kind Kind, cfg Config) (Summarizer, error) {
+	switch kind {
+	case KindTLDR, "":
+		return NewTLDRBag(), nil
+	case KindTextRank:
+		return NewTextRank(), nil
+	case KindOpenAI:
+		return NewOpenAI(cfg.OpenAIAPIKey, cfg.OpenAIModel), nil
+	case KindOllama:
+		return NewOllama(cfg.OllamaModel, cfg.OllamaURL), nil
+	default:
+		return nil, fmt.Errorf("unknown summarizer %q", kind)
+	}
+}
+
+// splitLines turns a newline-delimited LLM reply into a sentence slice,
+// dropping blank lines and truncating to at most n entries.
+func splitLines(text string, n int) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) > n {
+		lines = lines[:n]
+	}
+	return lines
+}
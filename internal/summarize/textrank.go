@@ -0,0 +1,188 @@
+package summarize
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TextRank summarizes content offline by ranking sentences with a graph-based
+// algorithm: sentences are TF-IDF vectorized, connected by cosine similarity,
+// and scored with power-iteration PageRank.
+type TextRank struct{}
+
+// NewTextRank constructs a TextRank summarizer.
+func NewTextRank() *TextRank {
+	return &TextRank{}
+}
+
+var sentenceBoundary = regexp.MustCompile(`([.!?]+)\s+`)
+
+func (t *TextRank) Summarize(content string, n int) ([]string, error) {
+	if n < 1 {
+		return nil, errors.New("n should be greater than or equal to 1")
+	}
+
+	sentences := splitSentences(content)
+	if len(sentences) <= n {
+		return sentences, nil
+	}
+
+	vectors := tfidfVectors(sentences)
+	graph := similarityGraph(vectors)
+	scores := pageRank(graph, 0.85, 1e-4, 100)
+
+	type ranked struct {
+		index int
+		score float64
+	}
+	order := make([]ranked, len(sentences))
+	for i, score := range scores {
+		order[i] = ranked{index: i, score: score}
+	}
+	sort.Slice(order, func(i, j int) bool { return order[i].score > order[j].score })
+
+	top := order[:n]
+	sort.Slice(top, func(i, j int) bool { return top[i].index < top[j].index })
+
+	summary := make([]string, n)
+	for i, r := range top {
+		summary[i] = sentences[r.index]
+	}
+	return summary, nil
+}
+
+// splitSentences does a light, punctuation-based sentence split; it does not
+// try to handle abbreviations or decimal numbers specially. Boundary
+// punctuation (., !, ?) stays attached to the sentence it ends.
+func splitSentences(content string) []string {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return nil
+	}
+
+	var sentences []string
+	last := 0
+	for _, m := range sentenceBoundary.FindAllStringSubmatchIndex(content, -1) {
+		if s := strings.TrimSpace(content[last:m[3]]); s != "" {
+			sentences = append(sentences, s)
+		}
+		last = m[1]
+	}
+	if s := strings.TrimSpace(content[last:]); s != "" {
+		sentences = append(sentences, s)
+	}
+	return sentences
+}
+
+// tfidfVectors builds a TF-IDF weighted term vector per sentence, treating
+// each sentence as its own "document" for IDF purposes.
+func tfidfVectors(sentences []string) []map[string]float64 {
+	docFreq := make(map[string]int)
+	termFreqs := make([]map[string]int, len(sentences))
+
+	for i, sentence := range sentences {
+		tf := make(map[string]int)
+		for _, term := range strings.Fields(strings.ToLower(sentence)) {
+			tf[term]++
+		}
+		termFreqs[i] = tf
+		for term := range tf {
+			docFreq[term]++
+		}
+	}
+
+	n := float64(len(sentences))
+	vectors := make([]map[string]float64, len(sentences))
+	for i, tf := range termFreqs {
+		vec := make(map[string]float64, len(tf))
+		for term, count := range tf {
+			idf := math.Log(n / float64(docFreq[term]))
+			vec[term] = float64(count) * idf
+		}
+		vectors[i] = vec
+	}
+	return vectors
+}
+
+func cosineSimilarity(a, b map[string]float64) float64 {
+	var dot, normA, normB float64
+	for term, weight := range a {
+		dot += weight * b[term]
+		normA += weight * weight
+	}
+	for _, weight := range b {
+		normB += weight * weight
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// similarityGraph returns a dense sentence-by-sentence cosine similarity
+// matrix with a zero diagonal.
+func similarityGraph(vectors []map[string]float64) [][]float64 {
+	n := len(vectors)
+	graph := make([][]float64, n)
+	for i := range graph {
+		graph[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			sim := cosineSimilarity(vectors[i], vectors[j])
+			graph[i][j] = sim
+			graph[j][i] = sim
+		}
+	}
+	return graph
+}
+
+// pageRank runs power-iteration PageRank over a weighted graph until the L1
+// delta between successive iterations drops below tol, or maxIter is
+// reached.
+func pageRank(graph [][]float64, damping, tol float64, maxIter int) []float64 {
+	n := len(graph)
+	scores := make([]float64, n)
+	for i := range scores {
+		scores[i] = 1.0 / float64(n)
+	}
+
+	outWeight := make([]float64, n)
+	for i, row := range graph {
+		for _, w := range row {
+			outWeight[i] += w
+		}
+	}
+
+	for iter := 0; iter < maxIter; iter++ {
+		next := make([]float64, n)
+		for i := range next {
+			next[i] = (1 - damping) / float64(n)
+		}
+		for j := 0; j < n; j++ {
+			if outWeight[j] == 0 {
+				continue
+			}
+			for i := 0; i < n; i++ {
+				if graph[j][i] == 0 {
+					continue
+				}
+				next[i] += damping * (graph[j][i] / outWeight[j]) * scores[j]
+			}
+		}
+
+		var delta float64
+		for i := range scores {
+			delta += math.Abs(next[i] - scores[i])
+		}
+		scores = next
+		if delta < tol {
+			break
+		}
+	}
+
+	return scores
+}
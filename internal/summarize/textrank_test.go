@@ -0,0 +1,73 @@
+package summarize
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	cases := []struct {
+		content string
+		want    []string
+	}{
+		{"", nil},
+		{"   ", nil},
+		{"One sentence.", []string{"One sentence."}},
+		{"First one. Second one! Third one?", []string{"First one.", "Second one!", "Third one?"}},
+	}
+	for _, c := range cases {
+		got := splitSentences(c.content)
+		if len(got) != len(c.want) {
+			t.Fatalf("splitSentences(%q) = %v, want %v", c.content, got, c.want)
+		}
+		for i := range got {
+			if got[i] != c.want[i] {
+				t.Errorf("splitSentences(%q)[%d] = %q, want %q", c.content, i, got[i], c.want[i])
+			}
+		}
+	}
+}
+
+func TestPageRankSumsToOne(t *testing.T) {
+	graph := [][]float64{
+		{0, 1, 0},
+		{1, 0, 1},
+		{0, 1, 0},
+	}
+	scores := pageRank(graph, 0.85, 1e-4, 100)
+	if len(scores) != 3 {
+		t.Fatalf("len(scores) = %d, want 3", len(scores))
+	}
+
+	var sum float64
+	for _, s := range scores {
+		sum += s
+	}
+	if math.Abs(sum-1) > 1e-2 {
+		t.Errorf("scores sum to %v, want ~1", sum)
+	}
+	// The middle node is connected to both others, so it should rank
+	// highest.
+	if scores[1] <= scores[0] || scores[1] <= scores[2] {
+		t.Errorf("scores = %v, want middle node to score highest", scores)
+	}
+}
+
+func TestPageRankHandlesDisconnectedNode(t *testing.T) {
+	// Node 2 has no outgoing or incoming edges; pageRank must not divide
+	// by zero or hang.
+	graph := [][]float64{
+		{0, 1, 0},
+		{1, 0, 0},
+		{0, 0, 0},
+	}
+	scores := pageRank(graph, 0.85, 1e-4, 100)
+	if len(scores) != 3 {
+		t.Fatalf("len(scores) = %d, want 3", len(scores))
+	}
+	for i, s := range scores {
+		if math.IsNaN(s) || math.IsInf(s, 0) {
+			t.Errorf("scores[%d] = %v, want a finite number", i, s)
+		}
+	}
+}
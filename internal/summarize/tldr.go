@@ -0,0 +1,25 @@
+package summarize
+
+import (
+	"errors"
+
+	"github.com/JesusIslam/tldr"
+)
+
+// TLDRBag summarizes content with the github.com/JesusIslam/tldr bag-of-words
+// algorithm; this is succinct's original, offline default.
+type TLDRBag struct{}
+
+// NewTLDRBag constructs a TLDRBag summarizer.
+func NewTLDRBag() *TLDRBag {
+	return &TLDRBag{}
+}
+
+func (t *TLDRBag) Summarize(content string, n int) ([]string, error) {
+	if n < 1 {
+		return nil, errors.New("n should be greater than or equal to 1")
+	}
+
+	bag := tldr.New()
+	return bag.Summarize(content, n)
+}
@@ -0,0 +1,92 @@
+package tokens
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// TermCount pairs a term (word or n-gram) with its rank score: a raw
+// occurrence count in "count" mode, or a TF*IDF weight in "tfidf" mode.
+type TermCount struct {
+	Term  string
+	Score float64
+}
+
+// Corpus accumulates term document-frequencies across every document in a
+// run, so that TF-IDF ranking can compare a document's terms against the
+// rest of the corpus.
+type Corpus struct {
+	mu       sync.Mutex
+	docFreq  map[string]int
+	docCount int
+}
+
+// NewCorpus constructs an empty Corpus.
+func NewCorpus() *Corpus {
+	return &Corpus{docFreq: make(map[string]int)}
+}
+
+// Add registers one document's terms for document-frequency purposes. Call
+// it once per document before ranking any of them.
+func (c *Corpus) Add(terms []string) {
+	seen := make(map[string]bool, len(terms))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.docCount++
+	for _, t := range terms {
+		if seen[t] {
+			continue
+		}
+		seen[t] = true
+		c.docFreq[t]++
+	}
+}
+
+// Rank scores a document's terms and returns the top `number` in descending
+// order. mode "tfidf" weights each term's count by the smoothed idf
+// log(N/df)+1, not by raw log(N/df), using the document frequencies
+// accumulated via Add; any other mode ranks by raw count.
+func (c *Corpus) Rank(terms []string, mode string, number int) []TermCount {
+	counts := make(map[string]int, len(terms))
+	for _, t := range terms {
+		counts[t]++
+	}
+
+	ranked := make([]TermCount, 0, len(counts))
+	for term, count := range counts {
+		ranked = append(ranked, TermCount{Term: term, Score: c.score(term, count, mode)})
+	}
+
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Term < ranked[j].Term
+	})
+
+	if len(ranked) > number {
+		ranked = ranked[:number]
+	}
+	return ranked
+}
+
+func (c *Corpus) score(term string, count int, mode string) float64 {
+	if mode != "tfidf" {
+		return float64(count)
+	}
+
+	c.mu.Lock()
+	df, n := c.docFreq[term], c.docCount
+	c.mu.Unlock()
+
+	if df == 0 {
+		return float64(count)
+	}
+	// Smoothed idf (log(N/df)+1) instead of raw log(N/df): with a
+	// single-document corpus every term's df equals N, which would
+	// otherwise collapse every score to zero and make the "top words"
+	// selection depend on map iteration order.
+	return float64(count) * (math.Log(float64(n)/float64(df)) + 1)
+}
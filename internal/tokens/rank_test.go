@@ -0,0 +1,70 @@
+package tokens
+
+import "testing"
+
+func TestCorpusRankCount(t *testing.T) {
+	c := NewCorpus()
+	terms := []string{"go", "go", "rust", "go"}
+	c.Add(terms)
+
+	ranked := c.Rank(terms, "count", 2)
+	if len(ranked) != 2 {
+		t.Fatalf("len(ranked) = %d, want 2", len(ranked))
+	}
+	if ranked[0].Term != "go" || ranked[0].Score != 3 {
+		t.Errorf("ranked[0] = %+v, want {go 3}", ranked[0])
+	}
+	if ranked[1].Term != "rust" || ranked[1].Score != 1 {
+		t.Errorf("ranked[1] = %+v, want {rust 1}", ranked[1])
+	}
+}
+
+func TestCorpusRankTFIDFSingleDocument(t *testing.T) {
+	// With only one document in the corpus, every term's df equals
+	// docCount, so a naive log(N/df) idf would collapse every score to
+	// zero. Scores must stay positive and ordered by count.
+	c := NewCorpus()
+	terms := []string{"go", "go", "rust"}
+	c.Add(terms)
+
+	ranked := c.Rank(terms, "tfidf", 2)
+	if len(ranked) != 2 {
+		t.Fatalf("len(ranked) = %d, want 2", len(ranked))
+	}
+	if ranked[0].Score <= 0 {
+		t.Fatalf("ranked[0].Score = %v, want > 0", ranked[0].Score)
+	}
+	if ranked[0].Term != "go" {
+		t.Errorf("ranked[0].Term = %q, want %q", ranked[0].Term, "go")
+	}
+	if ranked[0].Score <= ranked[1].Score {
+		t.Errorf("ranked[0].Score (%v) should exceed ranked[1].Score (%v)", ranked[0].Score, ranked[1].Score)
+	}
+}
+
+func TestCorpusRankTFIDFRareTermScoresHigher(t *testing.T) {
+	c := NewCorpus()
+	c.Add([]string{"common", "rare"})
+	c.Add([]string{"common", "common"})
+
+	ranked := c.Rank([]string{"common", "rare"}, "tfidf", 2)
+	if len(ranked) != 2 {
+		t.Fatalf("len(ranked) = %d, want 2", len(ranked))
+	}
+	if ranked[0].Term != "rare" {
+		t.Errorf("ranked[0].Term = %q, want %q (a term appearing in fewer documents should outrank an equally-frequent-in-this-doc but corpus-common one)", ranked[0].Term, "rare")
+	}
+}
+
+func TestCorpusRankIsDeterministicOnTies(t *testing.T) {
+	c := NewCorpus()
+	terms := []string{"alpha", "beta"}
+	c.Add(terms)
+
+	for i := 0; i < 10; i++ {
+		ranked := c.Rank(terms, "count", 2)
+		if ranked[0].Term != "alpha" || ranked[1].Term != "beta" {
+			t.Fatalf("iteration %d: ranked = %+v, want alphabetical tie-break [alpha beta]", i, ranked)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package tokens
+
+import "strings"
+
+// stemEnglish is a compact, suffix-stripping stemmer in the spirit of the
+// Porter/Snowball family: it folds common English plurals, -ing/-ed forms,
+// and the most frequent derivational suffixes down to a shared root. It is
+// not a full Snowball port, but it's enough to collapse near-duplicate
+// terms before ranking.
+func stemEnglish(word string) string {
+	if len(word) <= 3 {
+		return word
+	}
+	w := stripPlural(word)
+	w = stripVerbSuffix(w)
+	w = stripDerivational(w)
+	return w
+}
+
+func stripPlural(w string) string {
+	switch {
+	case strings.HasSuffix(w, "sses"):
+		return w[:len(w)-2]
+	case strings.HasSuffix(w, "ies") && len(w) > 4:
+		return w[:len(w)-3] + "y"
+	case strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") && !strings.HasSuffix(w, "us") && len(w) > 3:
+		return w[:len(w)-1]
+	}
+	return w
+}
+
+func stripVerbSuffix(w string) string {
+	switch {
+	case strings.HasSuffix(w, "eed") && len(w) > 5:
+		return w[:len(w)-1]
+	case strings.HasSuffix(w, "ing") && len(w) > 5:
+		return fixStem(w[:len(w)-3])
+	case strings.HasSuffix(w, "ed") && len(w) > 4:
+		return fixStem(w[:len(w)-2])
+	}
+	return w
+}
+
+// fixStem undoes the consonant doubling ("stopping" -> "stopp" -> "stop")
+// and silent-e dropping ("hoping" -> "hop" -> "hope") exposed by stripping
+// -ing/-ed.
+func fixStem(stem string) string {
+	n := len(stem)
+	if n >= 2 && stem[n-1] == stem[n-2] && isConsonant(stem, n-1) && stem[n-1] != 'l' && stem[n-1] != 's' && stem[n-1] != 'z' {
+		return stem[:n-1]
+	}
+	if n >= 3 && isConsonant(stem, n-1) && !isConsonant(stem, n-2) && isConsonant(stem, n-3) {
+		return stem + "e"
+	}
+	return stem
+}
+
+func isConsonant(s string, i int) bool {
+	switch s[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !isConsonant(s, i-1)
+	}
+	return true
+}
+
+var derivationalSuffixes = []struct{ suffix, replacement string }{
+	{"ational", "ate"},
+	{"tional", "tion"},
+	{"ization", "ize"},
+	{"fulness", "ful"},
+	{"ousness", "ous"},
+	{"iveness", "ive"},
+	{"ically", "ic"},
+	{"ation", "ate"},
+	{"alism", "al"},
+	{"aliti", "al"},
+	{"iviti", "ive"},
+	{"ness", ""},
+	{"ment", ""},
+	{"ful", ""},
+	{"ly", ""},
+}
+
+func stripDerivational(w string) string {
+	for _, rule := range derivationalSuffixes {
+		if strings.HasSuffix(w, rule.suffix) && len(w) > len(rule.suffix)+2 {
+			return w[:len(w)-len(rule.suffix)] + rule.replacement
+		}
+	}
+	return w
+}
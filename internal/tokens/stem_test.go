@@ -0,0 +1,25 @@
+package tokens
+
+import "testing"
+
+func TestStemEnglish(t *testing.T) {
+	cases := []struct {
+		word string
+		want string
+	}{
+		{"cats", "cat"},
+		{"boxes", "boxe"},
+		{"ponies", "pony"},
+		{"stopping", "stop"},
+		{"hoping", "hope"},
+		{"happiness", "happi"},
+		{"nationalization", "nationalize"},
+		{"cat", "cat"},
+		{"go", "go"},
+	}
+	for _, c := range cases {
+		if got := stemEnglish(c.word); got != c.want {
+			t.Errorf("stemEnglish(%q) = %q, want %q", c.word, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Package tokens turns page content into ranked terms: Unicode-aware
+// tokenization with optional stemming and n-grams, then either raw-count or
+// corpus-wide TF-IDF ranking.
+package tokens
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Tokenizer splits content into terms (words or n-grams), optionally
+// stemming each word first.
+type Tokenizer struct {
+	Stem  string // "en" or "none"
+	NGram int    // 1, 2, or 3
+}
+
+// NewTokenizer constructs a Tokenizer. An out-of-range ngram falls back to 1.
+func NewTokenizer(stem string, ngram int) *Tokenizer {
+	if ngram < 1 || ngram > 3 {
+		ngram = 1
+	}
+	return &Tokenizer{Stem: stem, NGram: ngram}
+}
+
+// Tokenize extracts lowercase terms from content, dropping any word present
+// in excluded before stemming or n-gram assembly.
+func (t *Tokenizer) Tokenize(content string, excluded map[string]bool) []string {
+	words := splitWords(content)
+
+	filtered := words[:0]
+	for _, w := range words {
+		if excluded[w] {
+			continue
+		}
+		if t.Stem == "en" {
+			w = stemEnglish(w)
+		}
+		filtered = append(filtered, w)
+	}
+
+	return ngrams(filtered, t.NGram)
+}
+
+// splitWords breaks content into lowercase runs of unicode letters.
+func splitWords(content string) []string {
+	var words []string
+	var b strings.Builder
+	for _, r := range content {
+		if unicode.IsLetter(r) {
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+		if b.Len() > 0 {
+			words = append(words, b.String())
+			b.Reset()
+		}
+	}
+	if b.Len() > 0 {
+		words = append(words, b.String())
+	}
+	return words
+}
+
+// ngrams joins consecutive words into n-length phrases; n<=1, or fewer than
+// n words, returns words unchanged.
+func ngrams(words []string, n int) []string {
+	if n <= 1 || len(words) < n {
+		return words
+	}
+	grams := make([]string, 0, len(words)-n+1)
+	for i := 0; i+n <= len(words); i++ {
+		grams = append(grams, strings.Join(words[i:i+n], " "))
+	}
+	return grams
+}
@@ -2,33 +2,54 @@ package main
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"errors"
 	"flag"
-	"fmt"
 	"golang.org/x/net/html"
+	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
-	"sort"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/JesusIslam/tldr"
+	"github.com/codingo/succinct/internal/extract"
+	"github.com/codingo/succinct/internal/httpx"
+	"github.com/codingo/succinct/internal/politeness"
+	"github.com/codingo/succinct/internal/report"
+	"github.com/codingo/succinct/internal/sitemap"
+	"github.com/codingo/succinct/internal/summarize"
+	"github.com/codingo/succinct/internal/tokens"
 )
 
-type WordFrequency struct {
-	word  string
-	count int
-}
-
 func main() {
 	targets := flag.String("t", "", "targets file (newline per webpage to load)")
 	exclude := flag.String("e", "", "exclude file (newline per word to exclude)")
 	number := flag.Int("n", 10, "the number of most common words to output")
 	threads := flag.Int("threads", 10, "the number of threads to use")
 	summarySentences := flag.Int("s", 3, "the number of sentences in the summary")
+	format := flag.String("format", "text", "output format: text, json, csv, or ndjson")
+	output := flag.String("o", "", "output file (default stdout)")
+	rps := flag.Float64("rps", 1.0, "max requests per second, per host")
+	delay := flag.Duration("delay", 0, "minimum delay between requests to the same host (overrides -rps if set)")
+	userAgent := flag.String("user-agent", "succinct/1.0 (+https://github.com/codingo/succinct)", "User-Agent header sent with every request")
+	summarizerKind := flag.String("summarizer", "tldr", "summarizer backend: tldr, textrank, openai, or ollama")
+	openaiKey := flag.String("openai-key", os.Getenv("OPENAI_API_KEY"), "API key for the openai summarizer")
+	openaiModel := flag.String("openai-model", "", "model name for the openai summarizer (default gpt-4o-mini)")
+	ollamaModel := flag.String("ollama-model", "", "model name for the ollama summarizer (default llama3)")
+	ollamaURL := flag.String("ollama-url", "", "base URL for the ollama summarizer (default http://localhost:11434)")
+	mode := flag.String("mode", "readable", "content extraction mode: full or readable")
+	stem := flag.String("stem", "none", "word stemming: en or none")
+	ngram := flag.Int("ngram", 1, "n-gram size for word frequency output (1-3)")
+	rank := flag.String("rank", "count", "word ranking mode: count or tfidf")
+	maxRedirects := flag.Int("max-redirects", 10, "maximum number of redirects to follow per URL")
+	maxRetries := flag.Int("max-retries", 2, "maximum number of retries on 429/5xx responses")
+	crawl := flag.Bool("crawl", false, "treat each target as a seed URL and expand it via robots.txt/sitemap.xml")
+	maxPages := flag.Int("max-pages", 50, "maximum number of pages to discover per seed when -crawl is set")
+	aggregate := flag.String("aggregate", "", "result aggregation: \"\" (per URL) or \"host\" (per host, for -crawl runs)")
 
 	flag.Parse()
 
@@ -36,6 +57,41 @@ func main() {
 		log.Fatal("Error: Missing -t or -targets flag")
 	}
 
+	if *mode != "full" && *mode != "readable" {
+		log.Fatalf("Error: unknown mode %q (want full or readable)", *mode)
+	}
+	if *stem != "en" && *stem != "none" {
+		log.Fatalf("Error: unknown stem %q (want en or none)", *stem)
+	}
+	if *ngram < 1 || *ngram > 3 {
+		log.Fatalf("Error: ngram must be between 1 and 3, got %d", *ngram)
+	}
+	if *rank != "count" && *rank != "tfidf" {
+		log.Fatalf("Error: unknown rank %q (want count or tfidf)", *rank)
+	}
+	if *aggregate != "" && *aggregate != "host" {
+		log.Fatalf("Error: unknown aggregate %q (want \"\" or host)", *aggregate)
+	}
+
+	outputFormat, err := report.ParseFormat(*format)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
+	summarizerKindParsed, err := summarize.ParseKind(*summarizerKind)
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+	summarizer, err := summarize.New(summarizerKindParsed, summarize.Config{
+		OpenAIAPIKey: *openaiKey,
+		OpenAIModel:  *openaiModel,
+		OllamaModel:  *ollamaModel,
+		OllamaURL:    *ollamaURL,
+	})
+	if err != nil {
+		log.Fatalf("Error: %v", err)
+	}
+
 	excludedWords, err := loadExcludedWords(*exclude)
 	if err != nil {
 		log.Fatalf("Error loading excluded words: %v", err)
@@ -46,30 +102,69 @@ func main() {
 		log.Fatalf("Error loading URLs: %v", err)
 	}
 
-	processURLs(urls, excludedWords, *threads, *number, *summarySentences)
+	effectiveRPS := *rps
+	if *delay > 0 {
+		effectiveRPS = 1 / delay.Seconds()
+	}
+	gate := politeness.NewGate(effectiveRPS, *userAgent)
+	tokenizer := tokens.NewTokenizer(*stem, *ngram)
+	client := httpx.NewClient(*userAgent, *maxRedirects, *maxRetries)
+
+	if *crawl {
+		urls = expandSeeds(urls, client.HTTPClient, gate, *userAgent, *maxPages)
+	}
+
+	results := processURLs(urls, excludedWords, *threads, *number, *summarySentences, gate, summarizer, *mode, tokenizer, *rank, client, *aggregate)
+
+	out := io.Writer(os.Stdout)
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("Error creating output file: %v", err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	if err := report.Write(out, outputFormat, results); err != nil {
+		log.Fatalf("Error writing %s output: %v", outputFormat, err)
+	}
 }
 
-// processURLs manages the concurrent processing of URLs
-func processURLs(urls []string, excludedWords map[string]bool, threads, number, summarySentences int) {
+// fetchedDoc holds one URL's fetch/summarize/tokenize output, ahead of the
+// corpus-wide ranking pass that turns it into a report.Result.
+type fetchedDoc struct {
+	url          string
+	finalURL     string
+	redirectHops []string
+	status       int
+	fetchedBytes int
+	elapsed      time.Duration
+	summary      []string
+	terms        []string
+}
+
+// processURLs manages the concurrent processing of URLs and returns one
+// report.Result per URL (or, with aggregateMode "host", one per distinct
+// host) that was fetched and summarized successfully. gate enforces
+// robots.txt and per-host rate limiting before each fetch, client performs
+// the redirect- and retry-aware HTTP fetch, summarizer produces each
+// result's summary sentences, mode selects between naive and
+// boilerplate-stripped content extraction, and rankMode selects between
+// raw-count and corpus-wide TF-IDF word ranking.
+func processURLs(urls []string, excludedWords map[string]bool, threads, number, summarySentences int, gate *politeness.Gate, summarizer summarize.Summarizer, mode string, tokenizer *tokens.Tokenizer, rankMode string, client *httpx.Client, aggregateMode string) []report.Result {
 	var sem = make(chan struct{}, threads)
 	var wg sync.WaitGroup
 
-	type result struct {
-		url          string
-		mostUsedWords []WordFrequency
-		summary       string
-	}
-
-	results := make(chan result)
+	docs := make(chan fetchedDoc)
+	var collected []fetchedDoc
+	done := make(chan struct{})
 
 	go func() {
-		for res := range results {
-			fmt.Printf("\nMost used words for %s:\n", res.url)
-			for _, wf := range res.mostUsedWords {
-				fmt.Printf("%s: %d\n", wf.word, wf.count)
-			}
-			fmt.Printf("\nSummary for %s:\n%s\n", res.url, res.summary)
+		for d := range docs {
+			collected = append(collected, d)
 		}
+		close(done)
 	}()
 
 	for _, url := range urls {
@@ -81,22 +176,59 @@ func processURLs(urls []string, excludedWords map[string]bool, threads, number,
 				wg.Done()
 			}()
 
+			start := time.Now()
+
 			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 			defer cancel()
 
-			bag := tldr.New()
-			content, err := fetchContent(ctx, url)
+			allowed, reason, err := gate.Allow(ctx, url)
+			if err != nil {
+				log.Printf("Error checking crawl politeness for %s: %v", url, err)
+				return
+			}
+			if !allowed {
+				log.Printf("Skipping %s: %s", url, reason)
+				return
+			}
+
+			fetched, err := client.Fetch(ctx, url)
+			if errors.Is(err, httpx.ErrNonHTML) {
+				log.Printf("Skipping %s: non-HTML content (%s)", url, fetched.ContentType)
+				return
+			}
 			if err != nil {
 				log.Printf("Error fetching content for %s: %v", url, err)
 				return
 			}
-			summary, err := summarizeContent(bag, content, summarySentences)
+
+			doc, err := html.Parse(bytes.NewReader(fetched.Body))
+			if err != nil {
+				log.Printf("Error parsing content for %s: %v", url, err)
+				return
+			}
+			var content string
+			if mode == "full" {
+				content = extractTextNodes(doc)
+			} else {
+				content = extract.MainContent(doc)
+			}
+
+			summary, err := summarizer.Summarize(content, summarySentences)
 			if err != nil {
 				log.Printf("Error summarizing content for %s: %v", url, err)
 				return
 			}
-			wordFrequency := getMostUsedWords(content, excludedWords, number)
-			results <- result{url: url, mostUsedWords: wordFrequency, summary: summary}
+			terms := tokenizer.Tokenize(content, excludedWords)
+			docs <- fetchedDoc{
+				url:          url,
+				finalURL:     fetched.FinalURL,
+				redirectHops: fetched.RedirectHops,
+				status:       fetched.StatusCode,
+				fetchedBytes: len(fetched.Body),
+				elapsed:      time.Since(start),
+				summary:      summary,
+				terms:        terms,
+			}
 		}(url)
 	}
 
@@ -104,7 +236,111 @@ func processURLs(urls []string, excludedWords map[string]bool, threads, number,
 		sem <- struct{}{}
 	}
 	wg.Wait()
-	close(results) // Close the results channel after all goroutines are done
+	close(docs) // Close the docs channel after all goroutines are done
+	<-done
+
+	if aggregateMode == "host" {
+		collected = aggregateByHost(collected)
+	}
+
+	corpus := tokens.NewCorpus()
+	for _, d := range collected {
+		corpus.Add(d.terms)
+	}
+
+	results := make([]report.Result, 0, len(collected))
+	for _, d := range collected {
+		ranked := corpus.Rank(d.terms, rankMode, number)
+		topWords := make([]report.WordCount, len(ranked))
+		for i, tc := range ranked {
+			topWords[i] = report.WordCount{Word: tc.Term, Count: tc.Score}
+		}
+		results = append(results, report.Result{
+			URL:          d.url,
+			FinalURL:     d.finalURL,
+			RedirectHops: d.redirectHops,
+			HTTPStatus:   d.status,
+			FetchedBytes: d.fetchedBytes,
+			ElapsedMS:    d.elapsed.Milliseconds(),
+			TopWords:     topWords,
+			Summary:      d.summary,
+		})
+	}
+
+	return results
+}
+
+// expandSeeds treats each URL in seeds as a crawl root: it discovers
+// further page URLs via sitemap.Discover and returns the seed followed by
+// its discovered pages, deduplicated, capped at maxPages per seed. A seed
+// whose discovery fails is kept on its own; the error is logged, not fatal.
+func expandSeeds(seeds []string, client *http.Client, gate *politeness.Gate, userAgent string, maxPages int) []string {
+	var expanded []string
+	seen := make(map[string]bool)
+
+	for _, seed := range seeds {
+		if !seen[seed] {
+			seen[seed] = true
+			expanded = append(expanded, seed)
+		}
+
+		pages, err := sitemap.Discover(context.Background(), client, gate, userAgent, seed, maxPages)
+		if err != nil {
+			log.Printf("Error discovering pages for %s: %v", seed, err)
+			continue
+		}
+		for _, page := range pages {
+			if seen[page] {
+				continue
+			}
+			seen[page] = true
+			expanded = append(expanded, page)
+		}
+	}
+
+	return expanded
+}
+
+// hostOf returns the hostname component of rawURL, or rawURL itself if it
+// doesn't parse as a URL with a host.
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+	return u.Host
+}
+
+// aggregateByHost merges docs sharing a host into a single fetchedDoc per
+// host, summing byte counts and elapsed time and concatenating summaries
+// and terms so the corpus ranking pass treats each host as one document.
+// The first doc seen for a host supplies the representative status,
+// finalURL, and redirectHops.
+func aggregateByHost(docs []fetchedDoc) []fetchedDoc {
+	byHost := make(map[string]*fetchedDoc)
+	var order []string
+
+	for _, d := range docs {
+		host := hostOf(d.url)
+		agg, ok := byHost[host]
+		if !ok {
+			copied := d
+			copied.url = host
+			byHost[host] = &copied
+			order = append(order, host)
+			continue
+		}
+		agg.fetchedBytes += d.fetchedBytes
+		agg.elapsed += d.elapsed
+		agg.summary = append(agg.summary, d.summary...)
+		agg.terms = append(agg.terms, d.terms...)
+	}
+
+	merged := make([]fetchedDoc, 0, len(order))
+	for _, host := range order {
+		merged = append(merged, *byHost[host])
+	}
+	return merged
 }
 
 // loadExcludedWords reads the excluded words file and returns a map of excluded words
@@ -155,26 +391,6 @@ func loadURLs(filename string) ([]string, error) {
 	return urls, nil
 }
 
-// formatURL validates and formats the URL with the correct protocol
-func formatURL(url string) (string, error) {
-	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
-		url = "https://" + url
-	}
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return "", err
-	}
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", err
-	}
-	resp.Body.Close()
-	return url, nil
-}
-
 // extractTextNodes extracts the text nodes from an HTML node and returns the concatenated text content
 func extractTextNodes(n *html.Node) string {
 	if n.Type == html.TextNode {
@@ -189,73 +405,3 @@ func extractTextNodes(n *html.Node) string {
 	}
 	return text
 }
-
-// fetchContent fetches the content of the given URL and returns it as a string
-func fetchContent(ctx context.Context, url string) (string, error) {
-	formattedURL, err := formatURL(url)
-	if err != nil {
-		return "", err
-	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, formattedURL, nil)
-	if err != nil {
-		return "", err
-	}
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
-
-	doc, err := html.Parse(resp.Body)
-	if err != nil {
-		return "", err
-	}
-
-	// Extract the text content from the HTML body
-	content := extractTextNodes(doc)
-
-	return content, nil
-}
-
-// summarizeContent generates a summary of the content using the tldr.Bag package
-func summarizeContent(bag *tldr.Bag, content string, summarySentences int) (string, error) {
-	if summarySentences < 1 {
-		return "", errors.New("summarySentences should be greater than or equal to 1")
-	}
-
-	summary, err := bag.Summarize(content, summarySentences)
-	if err != nil {
-		return "", err
-	}
-
-	return strings.Join(summary, " "), nil
-}
-
-// getMostUsedWords calculates the most used words in the content and returns a slice of WordFrequency
-func getMostUsedWords(content string, excludedWords map[string]bool, number int) []WordFrequency {
-	words := strings.Fields(content)
-	wordCounts := make(map[string]int)
-
-	for _, word := range words {
-		word = strings.ToLower(word)
-		if !excludedWords[word] {
-			wordCounts[word]++
-		}
-	}
-
-	wordFrequency := make([]WordFrequency, 0, len(wordCounts))
-
-	for word, count := range wordCounts {
-		wordFrequency = append(wordFrequency, WordFrequency{word: word, count: count})
-	}
-
-	sort.Slice(wordFrequency, func(i, j int) bool {
-		return wordFrequency[i].count > wordFrequency[j].count
-	})
-
-	if len(wordFrequency) > number {
-		wordFrequency = wordFrequency[:number]
-	}
-
-	return wordFrequency
-}